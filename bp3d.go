@@ -1,10 +1,8 @@
 package bp3d
 
 import (
-	"errors"
 	"fmt"
 	"math"
-	"sort"
 )
 
 // Bin represents a container in which items will be put into.
@@ -16,6 +14,11 @@ type Bin struct {
 	MaxWeight float64
 
 	Items []*Item // Items that packed in this bin
+
+	MaxLength float64 // max(Width, Height, Depth), precomputed at NewBin
+
+	itemsVolume float64 // cached sum of Items' volume, maintained by PutItem
+	itemsWeight float64 // cached sum of Items' weight, maintained by PutItem
 }
 
 type BinSlice []*Bin
@@ -47,6 +50,7 @@ func NewBin(name string, w, h, d, mw float64) *Bin {
 		Depth:     d,
 		MaxWeight: mw,
 		Items:     make([]*Item, 0),
+		MaxLength: math.Max(w, math.Max(h, d)),
 	}
 }
 
@@ -77,11 +81,12 @@ func (b *Bin) GetVolume() float64 {
 
 // GetUsedVolume returns the volume consumed by items in the bin.
 func (b *Bin) GetUsedVolume() (result float64) {
-	result = 0.0
-	for _, item := range b.Items {
-		result += item.GetVolume()
-	}
-	return
+	return b.itemsVolume
+}
+
+// GetUsedWeight returns the weight of items already packed into the bin.
+func (b *Bin) GetUsedWeight() (result float64) {
+	return b.itemsWeight
 }
 
 // GetAvailableVolume returns bin's available volume (after items added).
@@ -99,8 +104,25 @@ func (b *Bin) GetMaxWeight() float64 {
 	return b.MaxWeight
 }
 
+// GetMaxLength returns the longest of bin's width, height, and depth.
+func (b *Bin) GetMaxLength() float64 {
+	return b.MaxLength
+}
+
 // PutItem tries to put item into pivot p of bin b.
-func (b *Bin) PutItem(item *Item, p Pivot) (fit bool) {
+func (b *Bin) PutItem(item *Item, p Pivot) (fit bool, err error) {
+	// Cheap rejections first, before paying for the 6-rotation dimension
+	// checks and O(n) intersection tests below.
+	if b.itemsVolume+item.GetVolume() > b.GetVolume() {
+		return false, nil
+	}
+	if b.MaxWeight > 0 && b.itemsWeight+item.Weight > b.MaxWeight {
+		return false, ErrWeightExceeded
+	}
+	if item.MaxLength > b.MaxLength {
+		return false, nil
+	}
+
 	item.Position = p
 	for i := 0; i < 6; i++ {
 		item.RotationType = RotationType(i)
@@ -117,8 +139,17 @@ func (b *Bin) PutItem(item *Item, p Pivot) (fit bool) {
 			}
 		}
 
+		if fit {
+			if violator := b.loadViolation(item); violator != nil {
+				fit = false
+				err = ErrLoadExceeded
+			}
+		}
+
 		if fit {
 			b.Items = append(b.Items, item)
+			b.itemsVolume += item.GetVolume()
+			b.itemsWeight += item.Weight
 		}
 
 		return
@@ -179,9 +210,17 @@ type Item struct {
 	Depth  float64
 	Weight float64
 
+	// Stacking constraints, checked by Bin.PutItem whenever a candidate
+	// placement would rest another item on top of this one.
+	Fragile      bool    // true: no item, weightless or not, may be placed above this one
+	MaxLoadAbove float64 // max combined weight of items resting above this one; 0 means unlimited
+	TopOnly      bool    // true: this item must remain exposed; same effect as Fragile, expressed separately for callers modeling "must stay on top" rather than "can't bear weight"
+
 	// Used during packer.Pack()
 	RotationType RotationType
 	Position     Pivot
+
+	MaxLength float64 // max(Width, Height, Depth), precomputed at NewItem
 }
 
 type ItemSlice []*Item
@@ -198,11 +237,12 @@ func (is ItemSlice) Swap(i, j int) {
 // weight w. The quantity defaults to one.
 func NewItem(name string, w, h, d, wg float64) *Item {
 	return &Item{
-		Name:   name,
-		Width:  w,
-		Height: h,
-		Depth:  d,
-		Weight: wg,
+		Name:      name,
+		Width:     w,
+		Height:    h,
+		Depth:     d,
+		Weight:    wg,
+		MaxLength: math.Max(w, math.Max(h, d)),
 	}
 }
 
@@ -230,6 +270,11 @@ func (i *Item) GetWeight() float64 {
 	return i.Weight
 }
 
+// GetMaxLength returns the longest of item's width, height, and depth.
+func (i *Item) GetMaxLength() float64 {
+	return i.MaxLength
+}
+
 func (i *Item) GetDimension() (d Dimension) {
 	switch i.RotationType {
 	case RotationType_WHD:
@@ -276,209 +321,3 @@ func rectIntersect(i1, i2 *Item, x, y Axis) bool {
 func (i *Item) String() string {
 	return fmt.Sprintf("%s(%vx%vx%v, weight: %v) pos(%s) rt(%s)", i.GetName(), i.GetWidth(), i.GetHeight(), i.GetDepth(), i.GetWeight(), i.Position, i.RotationType)
 }
-
-type Packer struct {
-	FewestBoxes bool
-	Bins        []*Bin
-	Items       []*Item
-	UnfitItems  []*Item // items that don't fit to any bin
-}
-
-func NewPacker() *Packer {
-	return &Packer{
-		FewestBoxes: false,
-		Bins:        make([]*Bin, 0),
-		Items:       make([]*Item, 0),
-		UnfitItems:  make([]*Item, 0),
-	}
-}
-
-func (p *Packer) AddBin(bins ...*Bin) {
-	p.Bins = append(p.Bins, bins...)
-}
-
-func (p *Packer) AddItem(items ...*Item) {
-	p.Items = append(p.Items, items...)
-}
-
-var (
-	ErrInvalidBinsVolume = errors.New("invalid bins volume")
-	ErrUnfitItemsExist   = errors.New("unfit items existing")
-	ErrNoBins            = errors.New("no bins in packer")
-	ErrNoItems           = errors.New("no items in packer")
-)
-
-func (p *Packer) Pack() error {
-	sort.Sort(BinSlice(p.Bins))   // 昇順
-	sort.Sort(ItemSlice(p.Items)) // 降順
-	if len(p.Bins) == 0 {
-		return ErrNoBins
-	}
-	if len(p.Items) == 0 {
-		return ErrNoItems
-	}
-
-	maxVolumeItem := p.Items[0]
-	maxVolumeBin := p.Bins[len(p.Bins)-1]
-	if maxVolumeBin.GetVolume() < maxVolumeItem.GetVolume() {
-		return ErrInvalidBinsVolume
-	}
-
-	itemVolumeSum := 0.0
-	binVolumeSum := 0.0
-
-	for _, item := range p.Items {
-		itemVolumeSum += item.GetVolume()
-	}
-	for _, bin := range p.Bins {
-		binVolumeSum += bin.GetVolume()
-	}
-	if binVolumeSum < itemVolumeSum {
-		return ErrInvalidBinsVolume
-	}
-
-	if p.FewestBoxes {
-		// Is there a bin that might hold all of the items?
-		for _, bin := range p.Bins { // NOTE: sorted from smallest to largest.
-			if bin.GetVolume() >= itemVolumeSum && len(p.Items) > 0 {
-				// Yes... let's use it.
-				p.Items = p.packToBin(bin, p.Items)
-			}
-		}
-		for len(p.Items) > 0 {
-			// No... so we want to attempt to pack with the
-			// fewest possible boxes consuming the most volume.
-			// How best to do that?
-			// Calculate needed volume.
-			need := 0.0
-			for _, item := range p.Items {
-				need += item.GetVolume()
-			}
-			// Find volume closest to this.
-			found := 0.0
-			var foundBin *Bin
-			for _, bin := range p.Bins {
-				if bin.GetAvailableVolume() >= found && bin.GetAvailableVolume() < need {
-					foundBin = bin
-					found = foundBin.GetAvailableVolume()
-				}
-			}
-			if foundBin != nil {
-				p.Items = p.packToBin(foundBin, p.Items)
-			} else {
-				// Nothing more we can do.
-				break
-			}
-		}
-	}
-
-	for len(p.Items) > 0 {
-		bin := p.FindFittedBin(p.Items[0])
-		if bin == nil {
-			p.unfitItem()
-			continue
-		}
-
-		p.Items = p.packToBin(bin, p.Items)
-	}
-
-	if len(p.UnfitItems) > 0 {
-		return ErrUnfitItemsExist
-	}
-
-	return nil
-}
-
-// unfitItem moves p.Items[0] to p.UnfitItems.
-func (p *Packer) unfitItem() {
-	if len(p.Items) == 0 {
-		return
-	}
-	p.UnfitItems = append(p.UnfitItems, p.Items[0])
-	p.Items = p.Items[1:]
-}
-
-// packToBin packs items to bin b. Returns unpacked items.
-func (p *Packer) packToBin(b *Bin, items []*Item) (unpacked []*Item) {
-	if !b.PutItem(items[0], startPosition) {
-
-		if b2 := p.getBiggerBinThan(b); b2 != nil {
-			return p.packToBin(b2, items)
-		}
-
-		return p.Items
-	}
-
-	// Pack unpacked items.
-	for _, i := range items[1:] {
-		var fitted bool
-	lookup:
-
-		// Try available pivots in current bin that are not intersect with
-		// existing items in current bin.
-		for pt := 0; pt < 3; pt++ {
-			for _, ib := range b.Items {
-				var pv Pivot
-				switch Axis(pt) {
-				case WidthAxis:
-					pv = Pivot{ib.Position[0] + ib.GetWidth(), ib.Position[1], ib.Position[2]}
-				case HeightAxis:
-					pv = Pivot{ib.Position[0], ib.Position[1] + ib.GetHeight(), ib.Position[2]}
-				case DepthAxis:
-					pv = Pivot{ib.Position[0], ib.Position[1], ib.Position[2] + ib.GetDepth()}
-				}
-
-				if b.PutItem(i, pv) {
-					fitted = true
-					break lookup
-				}
-			}
-		}
-
-		if !fitted {
-			for b2 := p.getBiggerBinThan(b); b2 != nil; b2 = p.getBiggerBinThan(b) {
-				left := p.packToBin(b2, append(b2.Items, i))
-				if len(left) == 0 {
-					b = b2
-					fitted = true
-					break
-				}
-				b = b2
-			}
-
-			if !fitted {
-				unpacked = append(unpacked, i)
-			}
-		}
-	}
-
-	return
-}
-
-func (p *Packer) getBiggerBinThan(b *Bin) *Bin {
-	v := b.GetAvailableVolume()
-	for _, b2 := range p.Bins {
-		if b2.GetAvailableVolume() > v {
-			return b2
-		}
-	}
-	return nil
-}
-
-// FindFittedBin finds bin in which item i will be fitted into.
-func (p *Packer) FindFittedBin(i *Item) *Bin {
-	for _, b := range p.Bins {
-		if !b.PutItem(i, startPosition) {
-			continue
-		}
-
-		if len(b.Items) == 1 && b.Items[0] == i {
-			// Clear items in bin as we previously just check whether item i
-			// fits in bin b.
-			b.Items = []*Item{}
-		}
-
-		return b
-	}
-	return nil
-}
@@ -0,0 +1,68 @@
+package bp3d
+
+import "testing"
+
+func TestPutItemCachesUsedVolumeAndWeight(t *testing.T) {
+	bin := NewBin("bin", 100, 100, 100, 1000)
+	item := NewItem("item", 10, 10, 10, 5)
+
+	if fit, err := bin.PutItem(item, Pivot{0, 0, 0}); !fit || err != nil {
+		t.Fatalf("PutItem() = %v, %v, want true, nil", fit, err)
+	}
+
+	if got := bin.GetUsedVolume(); got != 1000 {
+		t.Errorf("GetUsedVolume() = %v, want 1000", got)
+	}
+	if got := bin.GetUsedWeight(); got != 5 {
+		t.Errorf("GetUsedWeight() = %v, want 5", got)
+	}
+}
+
+func TestPutItemRejectsOverMaxWeight(t *testing.T) {
+	bin := NewBin("bin", 100, 100, 100, 10)
+
+	first := NewItem("first", 10, 10, 10, 6)
+	if fit, err := bin.PutItem(first, Pivot{0, 0, 0}); !fit || err != nil {
+		t.Fatalf("PutItem(first) = %v, %v, want true, nil", fit, err)
+	}
+
+	second := NewItem("second", 10, 10, 10, 6)
+	fit, err := bin.PutItem(second, Pivot{20, 0, 0})
+	if fit {
+		t.Fatal("PutItem(second) fit, want rejection for exceeding MaxWeight")
+	}
+	if err != ErrWeightExceeded {
+		t.Errorf("PutItem(second) err = %v, want ErrWeightExceeded", err)
+	}
+}
+
+func TestPutItemUnlimitedWeightWhenMaxWeightZero(t *testing.T) {
+	bin := NewBin("bin", 100, 100, 100, 0)
+	item := NewItem("item", 10, 10, 10, 1000)
+
+	if fit, err := bin.PutItem(item, Pivot{0, 0, 0}); !fit || err != nil {
+		t.Fatalf("PutItem() = %v, %v, want true, nil when MaxWeight is 0 (unlimited)", fit, err)
+	}
+}
+
+func TestPutItemFastRejectsOversizedMaxLength(t *testing.T) {
+	bin := NewBin("bin", 10, 10, 10, 1000)
+	item := NewItem("too-long", 20, 1, 1, 1)
+
+	fit, err := bin.PutItem(item, Pivot{0, 0, 0})
+	if fit || err != nil {
+		t.Errorf("PutItem() = %v, %v, want false, nil for an item longer than the bin's longest side", fit, err)
+	}
+}
+
+func TestGetMaxLengthReturnsLongestSide(t *testing.T) {
+	bin := NewBin("bin", 3, 7, 5, 1000)
+	if got := bin.GetMaxLength(); got != 7 {
+		t.Errorf("bin.GetMaxLength() = %v, want 7", got)
+	}
+
+	item := NewItem("item", 3, 7, 5, 1)
+	if got := item.GetMaxLength(); got != 7 {
+		t.Errorf("item.GetMaxLength() = %v, want 7", got)
+	}
+}
@@ -0,0 +1,21 @@
+package bp3d
+
+// CopyPtr returns a pointer to a shallow copy of v.
+//
+// It's used throughout the packer to clone Bins and Items before a packing
+// run so that the caller's original slices are never mutated.
+func CopyPtr[T any](v *T) *T {
+	cp := *v
+	return &cp
+}
+
+// CopySlicePtr returns a new slice of pointers, each pointing to a shallow
+// copy of the corresponding element of s. The input slice itself is left
+// untouched.
+func CopySlicePtr[T any](s []*T) []*T {
+	cp := make([]*T, len(s))
+	for i, v := range s {
+		cp[i] = CopyPtr(v)
+	}
+	return cp
+}
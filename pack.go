@@ -0,0 +1,317 @@
+package bp3d
+
+import (
+	"errors"
+	"sort"
+)
+
+// PackResult is the immutable outcome of a packing run. It carries the bins
+// that were packed, each with its own Items populated and positioned, along
+// with any items that didn't fit anywhere.
+//
+// A PackResult never aliases the bins or items passed in to PackBins; the
+// packer clones its inputs internally, so running PackBins again with
+// different strategies over the same original slices is always safe.
+type PackResult struct {
+	Bins       []*Bin
+	UnfitItems []*Item
+}
+
+type Packer struct {
+	FewestBoxes bool
+	Bins        []*Bin
+	Items       []*Item
+	UnfitItems  []*Item // items that don't fit to any bin
+
+	// Score ranks candidate PackResults for PackBest; higher is better.
+	// Defaults to DefaultScore (fewest bins used, then highest average
+	// utilization) when nil.
+	Score func(*PackResult) float64
+}
+
+func NewPacker() *Packer {
+	return &Packer{
+		FewestBoxes: false,
+		Bins:        make([]*Bin, 0),
+		Items:       make([]*Item, 0),
+		UnfitItems:  make([]*Item, 0),
+	}
+}
+
+func (p *Packer) AddBin(bins ...*Bin) {
+	p.Bins = append(p.Bins, bins...)
+}
+
+func (p *Packer) AddItem(items ...*Item) {
+	p.Items = append(p.Items, items...)
+}
+
+var (
+	ErrInvalidBinsVolume = errors.New("invalid bins volume")
+	ErrUnfitItemsExist   = errors.New("unfit items existing")
+	ErrNoBins            = errors.New("no bins in packer")
+	ErrNoItems           = errors.New("no items in packer")
+	ErrWeightExceeded    = errors.New("item would exceed bin's max weight")
+)
+
+// PackBins packs items into bins according to p.FewestBoxes and returns the
+// result as a PackResult. bins and items are deep-copied internally (see
+// CopyPtr/CopySlicePtr), so the slices passed in are never mutated and can
+// be reused across repeated calls, e.g. to compare sort strategies.
+func (p *Packer) PackBins(bins []*Bin, items []*Item) (*PackResult, error) {
+	cBins := CopySlicePtr(bins)
+	cItems := CopySlicePtr(items)
+	for _, b := range cBins {
+		b.Items = CopySlicePtr(b.Items)
+	}
+	sort.Sort(BinSlice(cBins))   // 昇順
+	sort.Sort(ItemSlice(cItems)) // 降順
+
+	s := &packRun{
+		fewestBoxes: p.FewestBoxes,
+		bins:        cBins,
+		items:       cItems,
+		pivotOrder:  defaultPivotOrder,
+	}
+
+	err := s.run()
+	result := &PackResult{Bins: s.bins, UnfitItems: s.unfitItems}
+	return result, err
+}
+
+// Pack runs the packing algorithm over the bins and items already added via
+// AddBin/AddItem, mutating Bins, Items, and UnfitItems in place.
+//
+// Deprecated: prefer PackBins, which returns an immutable PackResult and
+// leaves its inputs untouched so the same bins/items can be packed again
+// under a different strategy without cloning them by hand.
+func (p *Packer) Pack() error {
+	result, err := p.PackBins(p.Bins, p.Items)
+	if err != nil && err != ErrUnfitItemsExist {
+		return err
+	}
+
+	p.Bins = result.Bins
+	p.Items = p.Items[:0]
+	p.UnfitItems = result.UnfitItems
+
+	if len(p.UnfitItems) > 0 {
+		return ErrUnfitItemsExist
+	}
+	return nil
+}
+
+// FindFittedBin finds bin in which item i will be fitted into.
+//
+// Deprecated: this inspects p.Bins directly and is kept only for backwards
+// compatibility; prefer PackBins, which makes the same decision internally
+// without mutating shared state.
+func (p *Packer) FindFittedBin(i *Item) *Bin {
+	s := &packRun{bins: p.Bins}
+	return s.findFittedBin(i)
+}
+
+// defaultPivotOrder tries pivots along the width axis first, then height,
+// then depth -- the order the packer has always used.
+var defaultPivotOrder = [3]Axis{WidthAxis, HeightAxis, DepthAxis}
+
+// packRun holds the mutable working state of a single packing run so that
+// Pack can operate on cloned bins/items without touching the Packer itself.
+// bins and items must already be sorted the way the caller wants before
+// run is called; packRun itself doesn't impose an order, so PackBest can
+// drive the same algorithm with alternative sort/pivot strategies.
+type packRun struct {
+	fewestBoxes bool
+	bins        []*Bin
+	items       []*Item
+	unfitItems  []*Item
+	pivotOrder  [3]Axis
+}
+
+func (s *packRun) run() error {
+	if s.pivotOrder == ([3]Axis{}) {
+		s.pivotOrder = defaultPivotOrder
+	}
+	if len(s.bins) == 0 {
+		return ErrNoBins
+	}
+	if len(s.items) == 0 {
+		return ErrNoItems
+	}
+
+	maxVolumeItem := s.items[0]
+	for _, item := range s.items[1:] {
+		if item.GetVolume() > maxVolumeItem.GetVolume() {
+			maxVolumeItem = item
+		}
+	}
+	maxVolumeBin := s.bins[0]
+	for _, bin := range s.bins[1:] {
+		if bin.GetVolume() > maxVolumeBin.GetVolume() {
+			maxVolumeBin = bin
+		}
+	}
+	if maxVolumeBin.GetVolume() < maxVolumeItem.GetVolume() {
+		return ErrInvalidBinsVolume
+	}
+
+	itemVolumeSum := 0.0
+	binVolumeSum := 0.0
+
+	for _, item := range s.items {
+		itemVolumeSum += item.GetVolume()
+	}
+	for _, bin := range s.bins {
+		binVolumeSum += bin.GetVolume()
+	}
+	if binVolumeSum < itemVolumeSum {
+		return ErrInvalidBinsVolume
+	}
+
+	if s.fewestBoxes {
+		// Is there a bin that might hold all of the items?
+		for _, bin := range s.bins { // NOTE: in the caller's order; smallest to largest unless a Strategy sorts bins otherwise.
+			if bin.GetVolume() >= itemVolumeSum && len(s.items) > 0 {
+				// Yes... let's use it.
+				s.items = s.packToBin(bin, s.items)
+			}
+		}
+		for len(s.items) > 0 {
+			// No... so we want to attempt to pack with the
+			// fewest possible boxes consuming the most volume.
+			// How best to do that?
+			// Calculate needed volume.
+			need := 0.0
+			for _, item := range s.items {
+				need += item.GetVolume()
+			}
+			// Find volume closest to this.
+			found := 0.0
+			var foundBin *Bin
+			for _, bin := range s.bins {
+				if bin.GetAvailableVolume() >= found && bin.GetAvailableVolume() < need {
+					foundBin = bin
+					found = foundBin.GetAvailableVolume()
+				}
+			}
+			if foundBin != nil {
+				s.items = s.packToBin(foundBin, s.items)
+			} else {
+				// Nothing more we can do.
+				break
+			}
+		}
+	}
+
+	for len(s.items) > 0 {
+		bin := s.findFittedBin(s.items[0])
+		if bin == nil {
+			s.unfitItem()
+			continue
+		}
+
+		s.items = s.packToBin(bin, s.items)
+	}
+
+	if len(s.unfitItems) > 0 {
+		return ErrUnfitItemsExist
+	}
+
+	return nil
+}
+
+// unfitItem moves s.items[0] to s.unfitItems.
+func (s *packRun) unfitItem() {
+	if len(s.items) == 0 {
+		return
+	}
+	s.unfitItems = append(s.unfitItems, s.items[0])
+	s.items = s.items[1:]
+}
+
+// packToBin packs items to bin b. Returns unpacked items.
+func (s *packRun) packToBin(b *Bin, items []*Item) (unpacked []*Item) {
+	if fit, _ := b.PutItem(items[0], startPosition); !fit {
+
+		if b2 := s.getBiggerBinThan(b); b2 != nil {
+			return s.packToBin(b2, items)
+		}
+
+		return s.items
+	}
+
+	// Pack unpacked items.
+	for _, i := range items[1:] {
+		var fitted bool
+	lookup:
+
+		// Try available pivots in current bin that are not intersect with
+		// existing items in current bin.
+		for _, axis := range s.pivotOrder {
+			for _, ib := range b.Items {
+				var pv Pivot
+				switch axis {
+				case WidthAxis:
+					pv = Pivot{ib.Position[0] + ib.GetWidth(), ib.Position[1], ib.Position[2]}
+				case HeightAxis:
+					pv = Pivot{ib.Position[0], ib.Position[1] + ib.GetHeight(), ib.Position[2]}
+				case DepthAxis:
+					pv = Pivot{ib.Position[0], ib.Position[1], ib.Position[2] + ib.GetDepth()}
+				}
+
+				if fit, _ := b.PutItem(i, pv); fit {
+					fitted = true
+					break lookup
+				}
+			}
+		}
+
+		if !fitted {
+			for b2 := s.getBiggerBinThan(b); b2 != nil; b2 = s.getBiggerBinThan(b) {
+				left := s.packToBin(b2, append(b2.Items, i))
+				if len(left) == 0 {
+					b = b2
+					fitted = true
+					break
+				}
+				b = b2
+			}
+
+			if !fitted {
+				unpacked = append(unpacked, i)
+			}
+		}
+	}
+
+	return
+}
+
+func (s *packRun) getBiggerBinThan(b *Bin) *Bin {
+	v := b.GetAvailableVolume()
+	for _, b2 := range s.bins {
+		if b2.GetAvailableVolume() > v {
+			return b2
+		}
+	}
+	return nil
+}
+
+// findFittedBin finds the bin in which item i will be fitted.
+func (s *packRun) findFittedBin(i *Item) *Bin {
+	for _, b := range s.bins {
+		if fit, _ := b.PutItem(i, startPosition); !fit {
+			continue
+		}
+
+		if len(b.Items) == 1 && b.Items[0] == i {
+			// Clear items in bin as we previously just check whether item i
+			// fits in bin b.
+			b.Items = []*Item{}
+			b.itemsVolume = 0
+			b.itemsWeight = 0
+		}
+
+		return b
+	}
+	return nil
+}
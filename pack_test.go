@@ -0,0 +1,40 @@
+package bp3d
+
+import "testing"
+
+// TestPackMutatesInPlace locks in Pack's legacy signature and mutating
+// behavior: no arguments, acting on bins/items already added via
+// AddBin/AddItem.
+func TestPackMutatesInPlace(t *testing.T) {
+	p := NewPacker()
+	p.AddBin(NewBin("bin", 10, 10, 10, 1000))
+	p.AddItem(NewItem("item", 5, 5, 5, 1))
+
+	if err := p.Pack(); err != nil {
+		t.Fatalf("Pack() err = %v", err)
+	}
+
+	if len(p.Bins[0].Items) != 1 {
+		t.Fatalf("len(p.Bins[0].Items) = %d, want 1", len(p.Bins[0].Items))
+	}
+	if len(p.Items) != 0 {
+		t.Errorf("len(p.Items) = %d, want 0 after Pack() drains them", len(p.Items))
+	}
+}
+
+func TestPackBinsLeavesInputsUntouched(t *testing.T) {
+	bins := []*Bin{NewBin("bin", 10, 10, 10, 1000)}
+	items := []*Item{NewItem("item", 5, 5, 5, 1)}
+
+	result, err := NewPacker().PackBins(bins, items)
+	if err != nil {
+		t.Fatalf("PackBins() err = %v", err)
+	}
+
+	if len(bins[0].Items) != 0 {
+		t.Errorf("len(bins[0].Items) = %d, want 0 (PackBins must not mutate its inputs)", len(bins[0].Items))
+	}
+	if len(result.Bins[0].Items) != 1 {
+		t.Fatalf("len(result.Bins[0].Items) = %d, want 1", len(result.Bins[0].Items))
+	}
+}
@@ -0,0 +1,111 @@
+package bp3d
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrLoadExceeded is returned by Bin.PutItem when a candidate placement
+// would rest more weight on an existing item than its MaxLoadAbove allows,
+// or would place anything above an item marked Fragile or TopOnly.
+var ErrLoadExceeded = errors.New("placement would exceed an item's load constraints")
+
+// loadEpsilon is the tolerance used to decide whether one item's bottom
+// face is resting on another item's top face, matching the epsilon
+// Item.Intersect already uses for footprint comparisons.
+const loadEpsilon = 0.01
+
+// GetLoadAbove returns the items resting directly or transitively on top
+// of item within the bin, i.e. the full stack of weight item is bearing.
+// It's exposed so callers can validate a PackResult's stacking constraints
+// after the fact.
+func (b *Bin) GetLoadAbove(item *Item) []*Item {
+	seen := make(map[*Item]bool)
+	var above []*Item
+
+	var visit func(below *Item)
+	visit = func(below *Item) {
+		for _, ib := range b.Items {
+			if ib == below || seen[ib] {
+				continue
+			}
+			if restsOn(ib, below) {
+				seen[ib] = true
+				above = append(above, ib)
+				visit(ib)
+			}
+		}
+	}
+	visit(item)
+
+	return above
+}
+
+// loadViolation returns the first already-placed item whose stacking
+// constraints would be violated by resting item on top of it, or nil if
+// item can be placed without violating anything. item.Position and
+// item.RotationType must already reflect the candidate placement.
+func (b *Bin) loadViolation(item *Item) *Item {
+	for _, support := range b.transitiveSupports(item) {
+		if support.TopOnly || support.Fragile {
+			return support
+		}
+		if support.MaxLoadAbove > 0 {
+			current := 0.0
+			for _, loaded := range b.GetLoadAbove(support) {
+				current += loaded.Weight
+			}
+			if current+item.Weight > support.MaxLoadAbove {
+				return support
+			}
+		}
+	}
+	return nil
+}
+
+// transitiveSupports returns every already-placed item that item rests on,
+// directly or by resting on something that itself rests on them.
+func (b *Bin) transitiveSupports(item *Item) []*Item {
+	seen := make(map[*Item]bool)
+	var supports []*Item
+
+	var visit func(resting *Item)
+	visit = func(resting *Item) {
+		for _, ib := range b.Items {
+			if ib == resting || seen[ib] {
+				continue
+			}
+			if restsOn(resting, ib) {
+				seen[ib] = true
+				supports = append(supports, ib)
+				visit(ib)
+			}
+		}
+	}
+	visit(item)
+
+	return supports
+}
+
+// restsOn reports whether upper's bottom face sits on lower's top face:
+// their footprints overlap on the width/depth plane and upper's bottom
+// equals lower's top within loadEpsilon.
+func restsOn(upper, lower *Item) bool {
+	ld := lower.GetDimension()
+	if math.Abs(upper.Position[1]-(lower.Position[1]+ld[1])) > loadEpsilon {
+		return false
+	}
+	return footprintOverlap(upper, lower, WidthAxis) && footprintOverlap(upper, lower, DepthAxis)
+}
+
+// footprintOverlap checks whether items i1 and i2 overlap along axis,
+// using the same loose, epsilon-guarded comparison as rectIntersect.
+func footprintOverlap(i1, i2 *Item, axis Axis) bool {
+	d1 := i1.GetDimension()
+	d2 := i2.GetDimension()
+
+	c1 := i1.Position[axis] + d1[axis]/2
+	c2 := i2.Position[axis] + d2[axis]/2
+
+	return loadEpsilon < (d1[axis]+d2[axis])/2-math.Abs(c1-c2)
+}
@@ -0,0 +1,69 @@
+package bp3d
+
+import "testing"
+
+func TestPutItemRejectsLoadOverMaxLoadAbove(t *testing.T) {
+	bin := NewBin("bin", 100, 100, 100, 1000)
+
+	base := NewItem("base", 100, 10, 100, 50)
+	base.MaxLoadAbove = 5
+	if fit, err := bin.PutItem(base, Pivot{0, 0, 0}); !fit || err != nil {
+		t.Fatalf("PutItem(base) = %v, %v, want true, nil", fit, err)
+	}
+
+	heavy := NewItem("heavy", 100, 10, 100, 20)
+	fit, err := bin.PutItem(heavy, Pivot{0, 10, 0})
+	if fit {
+		t.Fatal("PutItem(heavy) fit, want rejection for exceeding base.MaxLoadAbove")
+	}
+	if err != ErrLoadExceeded {
+		t.Errorf("PutItem(heavy) err = %v, want ErrLoadExceeded", err)
+	}
+
+	light := NewItem("light", 100, 10, 100, 2)
+	if fit, err := bin.PutItem(light, Pivot{0, 10, 0}); !fit || err != nil {
+		t.Fatalf("PutItem(light) = %v, %v, want true, nil (within base.MaxLoadAbove)", fit, err)
+	}
+
+	if got := len(bin.GetLoadAbove(base)); got != 1 {
+		t.Errorf("len(GetLoadAbove(base)) = %d, want 1", got)
+	}
+}
+
+func TestPutItemFragileRejectsEvenWeightlessItem(t *testing.T) {
+	bin := NewBin("bin", 100, 100, 100, 1000)
+
+	base := NewItem("base", 100, 10, 100, 50)
+	base.Fragile = true
+	if fit, err := bin.PutItem(base, Pivot{0, 0, 0}); !fit || err != nil {
+		t.Fatalf("PutItem(base) = %v, %v, want true, nil", fit, err)
+	}
+
+	weightless := NewItem("weightless", 100, 10, 100, 0)
+	fit, err := bin.PutItem(weightless, Pivot{0, 10, 0})
+	if fit {
+		t.Fatal("PutItem(weightless) fit, want Fragile to reject anything above it, even with zero weight")
+	}
+	if err != ErrLoadExceeded {
+		t.Errorf("PutItem(weightless) err = %v, want ErrLoadExceeded", err)
+	}
+}
+
+func TestPutItemTopOnlyRejectsAnyItemAbove(t *testing.T) {
+	bin := NewBin("bin", 100, 100, 100, 1000)
+
+	base := NewItem("base", 100, 10, 100, 50)
+	base.TopOnly = true
+	if fit, err := bin.PutItem(base, Pivot{0, 0, 0}); !fit || err != nil {
+		t.Fatalf("PutItem(base) = %v, %v, want true, nil", fit, err)
+	}
+
+	above := NewItem("above", 100, 10, 100, 0)
+	fit, err := bin.PutItem(above, Pivot{0, 10, 0})
+	if fit {
+		t.Fatal("PutItem(above) fit, want TopOnly to reject anything above it")
+	}
+	if err != ErrLoadExceeded {
+		t.Errorf("PutItem(above) err = %v, want ErrLoadExceeded", err)
+	}
+}
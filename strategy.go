@@ -0,0 +1,226 @@
+package bp3d
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+// ItemSortOrder selects how a Strategy orders items before packing.
+type ItemSortOrder int
+
+const (
+	ItemSortVolumeDesc ItemSortOrder = iota
+	ItemSortLongestSideDesc
+	ItemSortWeightDesc
+	ItemSortShuffle
+)
+
+var itemSortOrderStrings = [...]string{
+	"ItemSortVolumeDesc",
+	"ItemSortLongestSideDesc",
+	"ItemSortWeightDesc",
+	"ItemSortShuffle",
+}
+
+func (o ItemSortOrder) String() string {
+	return itemSortOrderStrings[o]
+}
+
+// BinSortOrder selects how a Strategy orders bins before packing.
+type BinSortOrder int
+
+const (
+	BinSortVolumeAsc BinSortOrder = iota
+	BinSortVolumeDesc
+)
+
+var binSortOrderStrings = [...]string{
+	"BinSortVolumeAsc",
+	"BinSortVolumeDesc",
+}
+
+func (o BinSortOrder) String() string {
+	return binSortOrderStrings[o]
+}
+
+// Pivot-selection orders for use as Strategy.PivotOrder, named the same way
+// as RotationType's WHD/HDW/etc. constants.
+var (
+	PivotOrderWHD = [3]Axis{WidthAxis, HeightAxis, DepthAxis}
+	PivotOrderHDW = [3]Axis{HeightAxis, DepthAxis, WidthAxis}
+	PivotOrderDWH = [3]Axis{DepthAxis, WidthAxis, HeightAxis}
+)
+
+// Strategy is one combination of item sort, bin sort, and pivot-selection
+// order that PackBest can try. The zero value is a valid strategy: it
+// sorts items by descending volume, bins by ascending volume, and tries
+// pivots in width/height/depth order -- the same heuristic Pack itself
+// uses.
+type Strategy struct {
+	Name       string
+	ItemSort   ItemSortOrder
+	BinSort    BinSortOrder
+	PivotOrder [3]Axis
+
+	// Seed seeds the shuffle used when ItemSort is ItemSortShuffle, so a
+	// given Strategy always produces the same order.
+	Seed int64
+}
+
+// ErrNoStrategies is returned by PackBest when called with no strategies
+// to try.
+var ErrNoStrategies = errors.New("no strategies given")
+
+// DefaultScore is the default PackResult scoring function: fewest bins
+// used wins, ties are broken by highest average volume utilization across
+// the bins used. Higher is better.
+func DefaultScore(r *PackResult) float64 {
+	used := 0
+	utilSum := 0.0
+	for _, b := range r.Bins {
+		if len(b.Items) == 0 {
+			continue
+		}
+		used++
+		utilSum += b.GetVolumeUtilization()
+	}
+
+	avgUtil := 0.0
+	if used > 0 {
+		avgUtil = utilSum / float64(used)
+	}
+
+	// A single extra bin used should always outweigh any utilization
+	// difference, so bins dominate the score by a wide margin.
+	return -float64(used)*1e6 + avgUtil
+}
+
+// PackBest runs each of strategies against p's bins and items in its own
+// goroutine, operating on independent deep copies (see PackBins), and returns
+// the PackResult scored highest by p.Score (DefaultScore if nil). Ties are
+// broken by strategy position, not by which goroutine happens to finish
+// first, so the winner is reproducible given a fixed seed regardless of
+// scheduling.
+//
+// ctx bounds the wall-clock time PackBest waits for strategies to finish;
+// once ctx is done, PackBest returns the best result seen so far, or
+// ctx.Err() if none has finished yet. Strategies already running are not
+// forcibly stopped -- the packing algorithm has no cancellation points of
+// its own -- but PackBest stops waiting on them.
+func (p *Packer) PackBest(ctx context.Context, strategies []Strategy) (*PackResult, error) {
+	if len(strategies) == 0 {
+		return nil, ErrNoStrategies
+	}
+
+	score := p.Score
+	if score == nil {
+		score = DefaultScore
+	}
+
+	type outcome struct {
+		index  int
+		result *PackResult
+		err    error
+	}
+
+	results := make(chan outcome, len(strategies))
+	for i, st := range strategies {
+		i, st := i, st
+		go func() {
+			result, err := p.packStrategy(st)
+			results <- outcome{i, result, err}
+		}()
+	}
+
+	// best/bestIndex are updated incrementally as each outcome arrives on
+	// results, rather than scanned from a shared slice after the wait loop
+	// ends -- that way a result is only ever touched by the goroutine that
+	// sent it and by this loop picking it up off the channel, so there's no
+	// path where ctx.Done() wins the select while another goroutine is
+	// still writing. Ties prefer the lower index so the winner only depends
+	// on the strategies' input order, never on arrival order.
+	var best *PackResult
+	var bestScore float64
+	var bestIndex = -1
+	var firstErr error
+
+	completed := 0
+waitLoop:
+	for completed < len(strategies) {
+		select {
+		case <-ctx.Done():
+			break waitLoop
+		case out := <-results:
+			completed++
+			if out.err != nil && out.err != ErrUnfitItemsExist {
+				if firstErr == nil {
+					firstErr = out.err
+				}
+				continue
+			}
+			if s := score(out.result); best == nil || s > bestScore || (s == bestScore && out.index < bestIndex) {
+				best, bestScore, bestIndex = out.result, s, out.index
+			}
+		}
+	}
+
+	if best != nil {
+		return best, nil
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return nil, ctx.Err()
+}
+
+// packStrategy runs a single Strategy against deep copies of p's bins and
+// items.
+func (p *Packer) packStrategy(st Strategy) (*PackResult, error) {
+	bins := CopySlicePtr(p.Bins)
+	items := CopySlicePtr(p.Items)
+	for _, b := range bins {
+		b.Items = CopySlicePtr(b.Items)
+	}
+
+	sortBinsBy(bins, st.BinSort)
+	sortItemsBy(items, st.ItemSort, st.Seed)
+
+	pivotOrder := st.PivotOrder
+	if pivotOrder == ([3]Axis{}) {
+		pivotOrder = defaultPivotOrder
+	}
+
+	s := &packRun{
+		fewestBoxes: p.FewestBoxes,
+		bins:        bins,
+		items:       items,
+		pivotOrder:  pivotOrder,
+	}
+	err := s.run()
+	return &PackResult{Bins: s.bins, UnfitItems: s.unfitItems}, err
+}
+
+func sortBinsBy(bins []*Bin, order BinSortOrder) {
+	switch order {
+	case BinSortVolumeDesc:
+		sort.Sort(RevBinSlice(bins))
+	default:
+		sort.Sort(BinSlice(bins))
+	}
+}
+
+func sortItemsBy(items []*Item, order ItemSortOrder, seed int64) {
+	switch order {
+	case ItemSortLongestSideDesc:
+		sort.Slice(items, func(i, j int) bool { return items[i].MaxLength > items[j].MaxLength })
+	case ItemSortWeightDesc:
+		sort.Slice(items, func(i, j int) bool { return items[i].Weight > items[j].Weight })
+	case ItemSortShuffle:
+		r := rand.New(rand.NewSource(seed))
+		r.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+	default:
+		sort.Sort(ItemSlice(items))
+	}
+}
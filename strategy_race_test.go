@@ -0,0 +1,29 @@
+package bp3d
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestPackBestNoRaceUnderTightDeadline exercises the path where ctx expires
+// while strategy goroutines are still running, asserting (under -race) that
+// PackBest never reads a goroutine's result before that goroutine has sent
+// it.
+func TestPackBestNoRaceUnderTightDeadline(t *testing.T) {
+	p := NewPacker()
+	p.AddBin(NewBin("bin", 100, 100, 100, 1000))
+	p.AddItem(NewItem("item", 5, 5, 5, 1))
+
+	strategies := make([]Strategy, 50)
+	for i := range strategies {
+		strategies[i] = Strategy{Name: "s", BinSort: BinSortVolumeAsc}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+
+	for i := 0; i < 20; i++ {
+		_, _ = p.PackBest(ctx, strategies)
+	}
+}
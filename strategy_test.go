@@ -0,0 +1,46 @@
+package bp3d
+
+import (
+	"context"
+	"testing"
+)
+
+// TestPackBestTieBreaksByStrategyOrder pits two strategies that the scorer
+// considers exactly tied (a constant Score) against each other, but which
+// pack the item into a different bin. The winner must always be the first
+// strategy in the input slice, regardless of which goroutine happens to
+// finish first -- not whichever result lands on the channel first.
+func TestPackBestTieBreaksByStrategyOrder(t *testing.T) {
+	for trial := 0; trial < 20; trial++ {
+		p := NewPacker()
+		p.FewestBoxes = true
+		p.Score = func(*PackResult) float64 { return 0 }
+		p.AddBin(NewBin("small", 10, 10, 10, 1000)) // volume 1000
+		p.AddBin(NewBin("large", 20, 20, 20, 1000)) // volume 8000
+		p.AddItem(NewItem("item", 5, 5, 5, 1))      // fits either bin
+
+		strategies := []Strategy{
+			{Name: "ascending", BinSort: BinSortVolumeAsc},   // tries "small" first
+			{Name: "descending", BinSort: BinSortVolumeDesc}, // tries "large" first
+		}
+
+		result, err := p.PackBest(context.Background(), strategies)
+		if err != nil {
+			t.Fatalf("trial %d: PackBest() err = %v", trial, err)
+		}
+
+		var packed *Bin
+		for _, b := range result.Bins {
+			if len(b.Items) > 0 {
+				packed = b
+				break
+			}
+		}
+		if packed == nil {
+			t.Fatalf("trial %d: no bin received the item", trial)
+		}
+		if packed.GetName() != "small" {
+			t.Fatalf("trial %d: winning bin = %q, want \"small\" (first strategy in input order)", trial, packed.GetName())
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package viz exports packed bp3d.Bins to standard 3D interchange formats
+// (OBJ, glTF) so a Packer.Pack result can be opened in Blender or a browser
+// instead of eyeballed from coordinates.
+package viz
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/tvanriper/bp3d"
+)
+
+// corner indices shared by OBJ and glTF exporters: 0-3 are the face at
+// depth 0 (width/height plane), 4-7 are the face at full depth directly
+// behind them.
+var boxCornerOffsets = [8][3]float64{
+	{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {0, 1, 0},
+	{0, 0, 1}, {1, 0, 1}, {1, 1, 1}, {0, 1, 1},
+}
+
+// boxQuadFaces lists the 6 faces of a box as corner indices into
+// boxCornerOffsets, wound counter-clockwise when viewed from outside.
+var boxQuadFaces = [6][4]int{
+	{0, 3, 2, 1}, // depth 0
+	{4, 5, 6, 7}, // full depth
+	{0, 1, 5, 4}, // bottom
+	{1, 2, 6, 5}, // right
+	{2, 3, 7, 6}, // top
+	{3, 0, 4, 7}, // left
+}
+
+// boxEdges lists the 12 edges of a box as corner indices, used for the
+// bin's wireframe cage.
+var boxEdges = [12][2]int{
+	{0, 1}, {1, 2}, {2, 3}, {3, 0},
+	{4, 5}, {5, 6}, {6, 7}, {7, 4},
+	{0, 4}, {1, 5}, {2, 6}, {3, 7},
+}
+
+// boxCorners returns the 8 world-space corners of an axis-aligned box
+// positioned at pos and sized dim.
+func boxCorners(pos bp3d.Pivot, dim bp3d.Dimension) [8][3]float64 {
+	var corners [8][3]float64
+	for i, off := range boxCornerOffsets {
+		corners[i] = [3]float64{
+			pos[0] + off[0]*dim[0],
+			pos[1] + off[1]*dim[1],
+			pos[2] + off[2]*dim[2],
+		}
+	}
+	return corners
+}
+
+// itemColor deterministically derives an RGB color in [0,1] from an item's
+// name, so the same item name always renders the same color across runs.
+func itemColor(name string) (r, g, b float64) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	sum := h.Sum32()
+	r = float64(sum&0xFF) / 255.0
+	g = float64((sum>>8)&0xFF) / 255.0
+	b = float64((sum>>16)&0xFF) / 255.0
+	return
+}
+
+// materialName returns a stable, export-safe material/group name for an
+// item, used as the OBJ usemtl target and the glTF material name.
+func materialName(item *bp3d.Item) string {
+	return fmt.Sprintf("item_%s", sanitizeName(item.GetName()))
+}
+
+// sanitizeName replaces characters that OBJ group/material names and glTF
+// names can't safely contain with underscores.
+func sanitizeName(name string) string {
+	out := make([]rune, 0, len(name))
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_' || r == '-':
+			out = append(out, r)
+		default:
+			out = append(out, '_')
+		}
+	}
+	if len(out) == 0 {
+		return "item"
+	}
+	return string(out)
+}
@@ -0,0 +1,41 @@
+package viz
+
+import "testing"
+
+func TestSanitizeNameReplacesUnsafeCharacters(t *testing.T) {
+	if got := sanitizeName("box #1 (red)"); got != "box__1__red_" {
+		t.Errorf("sanitizeName(%q) = %q, want %q", "box #1 (red)", got, "box__1__red_")
+	}
+}
+
+func TestSanitizeNameEmptyFallsBackToItem(t *testing.T) {
+	if got := sanitizeName(""); got != "item" {
+		t.Errorf("sanitizeName(\"\") = %q, want %q", got, "item")
+	}
+	if got := sanitizeName("###"); got != "___" {
+		t.Errorf("sanitizeName(%q) = %q, want %q", "###", got, "___")
+	}
+}
+
+func TestItemColorIsDeterministicPerName(t *testing.T) {
+	r1, g1, b1 := itemColor("widget")
+	r2, g2, b2 := itemColor("widget")
+	if r1 != r2 || g1 != g2 || b1 != b2 {
+		t.Errorf("itemColor(\"widget\") = (%v,%v,%v) then (%v,%v,%v), want same color both times", r1, g1, b1, r2, g2, b2)
+	}
+	for _, c := range []float64{r1, g1, b1} {
+		if c < 0 || c > 1 {
+			t.Errorf("itemColor component = %v, want in [0,1]", c)
+		}
+	}
+}
+
+func TestBoxCornersOffsetByPosition(t *testing.T) {
+	corners := boxCorners([3]float64{1, 2, 3}, [3]float64{10, 20, 30})
+	if got := corners[0]; got != [3]float64{1, 2, 3} {
+		t.Errorf("corners[0] = %v, want %v", got, [3]float64{1, 2, 3})
+	}
+	if got := corners[6]; got != [3]float64{11, 22, 33} {
+		t.Errorf("corners[6] = %v, want %v", got, [3]float64{11, 22, 33})
+	}
+}
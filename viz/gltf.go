@@ -0,0 +1,293 @@
+package viz
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+
+	"github.com/tvanriper/bp3d"
+)
+
+const (
+	gltfComponentTypeFloat         = 5126
+	gltfComponentTypeUnsignedShort = 5123
+	gltfTypeVec3                   = "VEC3"
+	gltfTypeScalar                 = "SCALAR"
+	gltfModeLines                  = 1
+	gltfModeTriangles              = 4
+)
+
+type gltfDocument struct {
+	Asset       gltfAsset        `json:"asset"`
+	Scene       int              `json:"scene"`
+	Scenes      []gltfScene      `json:"scenes"`
+	Nodes       []gltfNode       `json:"nodes"`
+	Meshes      []gltfMesh       `json:"meshes"`
+	Materials   []gltfMaterial   `json:"materials,omitempty"`
+	Buffers     []gltfBuffer     `json:"buffers"`
+	BufferViews []gltfBufferView `json:"bufferViews"`
+	Accessors   []gltfAccessor   `json:"accessors"`
+}
+
+type gltfAsset struct {
+	Version   string `json:"version"`
+	Generator string `json:"generator"`
+}
+
+type gltfScene struct {
+	Nodes []int `json:"nodes"`
+}
+
+type gltfNode struct {
+	Name        string     `json:"name,omitempty"`
+	Mesh        *int       `json:"mesh,omitempty"`
+	Translation [3]float64 `json:"translation,omitempty"`
+}
+
+type gltfMesh struct {
+	Name       string          `json:"name,omitempty"`
+	Primitives []gltfPrimitive `json:"primitives"`
+}
+
+type gltfPrimitive struct {
+	Attributes map[string]int `json:"attributes"`
+	Indices    *int           `json:"indices,omitempty"`
+	Material   *int           `json:"material,omitempty"`
+	Mode       int            `json:"mode"`
+}
+
+type gltfMaterial struct {
+	Name                 string  `json:"name,omitempty"`
+	PBRMetallicRoughness gltfPBR `json:"pbrMetallicRoughness"`
+}
+
+type gltfPBR struct {
+	BaseColorFactor [4]float64 `json:"baseColorFactor"`
+	MetallicFactor  float64    `json:"metallicFactor"`
+	RoughnessFactor float64    `json:"roughnessFactor"`
+}
+
+type gltfBuffer struct {
+	URI        string `json:"uri"`
+	ByteLength int    `json:"byteLength"`
+}
+
+type gltfBufferView struct {
+	Buffer     int `json:"buffer"`
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+	Target     int `json:"target,omitempty"`
+}
+
+type gltfAccessor struct {
+	BufferView    int       `json:"bufferView"`
+	ComponentType int       `json:"componentType"`
+	Count         int       `json:"count"`
+	Type          string    `json:"type"`
+	Min           []float64 `json:"min,omitempty"`
+	Max           []float64 `json:"max,omitempty"`
+}
+
+// gltfBuilder accumulates one shared binary buffer (positions and indices
+// for every mesh) while a gltfDocument's meshes/accessors/bufferViews are
+// built up around it.
+type gltfBuilder struct {
+	buf bytes.Buffer
+	doc gltfDocument
+}
+
+func newGLTFBuilder() *gltfBuilder {
+	return &gltfBuilder{
+		doc: gltfDocument{
+			Asset: gltfAsset{Version: "2.0", Generator: "bp3d/viz"},
+			Scene: 0,
+		},
+	}
+}
+
+// addPositions appends a VEC3 float32 buffer view/accessor for pts and
+// returns its accessor index.
+func (g *gltfBuilder) addPositions(pts [][3]float64) int {
+	g.pad(4)
+	byteOffset := g.buf.Len()
+
+	min := pts[0]
+	max := pts[0]
+	for _, p := range pts {
+		for i := 0; i < 3; i++ {
+			if p[i] < min[i] {
+				min[i] = p[i]
+			}
+			if p[i] > max[i] {
+				max[i] = p[i]
+			}
+		}
+		for i := 0; i < 3; i++ {
+			_ = binary.Write(&g.buf, binary.LittleEndian, float32(p[i]))
+		}
+	}
+
+	bvIdx := len(g.doc.BufferViews)
+	g.doc.BufferViews = append(g.doc.BufferViews, gltfBufferView{
+		Buffer:     0,
+		ByteOffset: byteOffset,
+		ByteLength: g.buf.Len() - byteOffset,
+		Target:     34962, // ARRAY_BUFFER
+	})
+
+	accIdx := len(g.doc.Accessors)
+	g.doc.Accessors = append(g.doc.Accessors, gltfAccessor{
+		BufferView:    bvIdx,
+		ComponentType: gltfComponentTypeFloat,
+		Count:         len(pts),
+		Type:          gltfTypeVec3,
+		Min:           min[:],
+		Max:           max[:],
+	})
+	return accIdx
+}
+
+// addIndices appends a SCALAR uint16 buffer view/accessor for idx and
+// returns its accessor index.
+func (g *gltfBuilder) addIndices(idx []uint16) int {
+	g.pad(2)
+	byteOffset := g.buf.Len()
+	for _, v := range idx {
+		_ = binary.Write(&g.buf, binary.LittleEndian, v)
+	}
+
+	bvIdx := len(g.doc.BufferViews)
+	g.doc.BufferViews = append(g.doc.BufferViews, gltfBufferView{
+		Buffer:     0,
+		ByteOffset: byteOffset,
+		ByteLength: g.buf.Len() - byteOffset,
+		Target:     34963, // ELEMENT_ARRAY_BUFFER
+	})
+
+	accIdx := len(g.doc.Accessors)
+	g.doc.Accessors = append(g.doc.Accessors, gltfAccessor{
+		BufferView:    bvIdx,
+		ComponentType: gltfComponentTypeUnsignedShort,
+		Count:         len(idx),
+		Type:          gltfTypeScalar,
+	})
+	return accIdx
+}
+
+// pad aligns the buffer to align bytes, as glTF bufferViews must be
+// aligned to their component size.
+func (g *gltfBuilder) pad(align int) {
+	for g.buf.Len()%align != 0 {
+		g.buf.WriteByte(0)
+	}
+}
+
+// addBoxNode adds a solid, colored triangle-mesh box node named name,
+// positioned at pos and sized dim, with baseColorFactor rgb.
+func (g *gltfBuilder) addBoxNode(name string, pos bp3d.Pivot, dim bp3d.Dimension, r, gr, b float64) {
+	corners := boxCorners(pos, dim)
+	pts := make([][3]float64, len(corners))
+	for i, c := range corners {
+		pts[i] = c
+	}
+	posAcc := g.addPositions(pts)
+
+	var idx []uint16
+	for _, f := range boxQuadFaces {
+		idx = append(idx,
+			uint16(f[0]), uint16(f[1]), uint16(f[2]),
+			uint16(f[0]), uint16(f[2]), uint16(f[3]),
+		)
+	}
+	idxAcc := g.addIndices(idx)
+
+	matIdx := len(g.doc.Materials)
+	g.doc.Materials = append(g.doc.Materials, gltfMaterial{
+		Name: name,
+		PBRMetallicRoughness: gltfPBR{
+			BaseColorFactor: [4]float64{r, gr, b, 1},
+			MetallicFactor:  0,
+			RoughnessFactor: 1,
+		},
+	})
+
+	meshIdx := len(g.doc.Meshes)
+	g.doc.Meshes = append(g.doc.Meshes, gltfMesh{
+		Name: name,
+		Primitives: []gltfPrimitive{{
+			Attributes: map[string]int{"POSITION": posAcc},
+			Indices:    &idxAcc,
+			Material:   &matIdx,
+			Mode:       gltfModeTriangles,
+		}},
+	})
+
+	nodeIdx := len(g.doc.Nodes)
+	g.doc.Nodes = append(g.doc.Nodes, gltfNode{Name: name, Mesh: &meshIdx})
+	g.doc.Scenes[0].Nodes = append(g.doc.Scenes[0].Nodes, nodeIdx)
+}
+
+// addCageNode adds a wireframe line-mesh node for the bin's bounding box.
+func (g *gltfBuilder) addCageNode(dim bp3d.Dimension) {
+	corners := boxCorners(bp3d.Pivot{0, 0, 0}, dim)
+	pts := make([][3]float64, len(corners))
+	for i, c := range corners {
+		pts[i] = c
+	}
+	posAcc := g.addPositions(pts)
+
+	var idx []uint16
+	for _, e := range boxEdges {
+		idx = append(idx, uint16(e[0]), uint16(e[1]))
+	}
+	idxAcc := g.addIndices(idx)
+
+	meshIdx := len(g.doc.Meshes)
+	g.doc.Meshes = append(g.doc.Meshes, gltfMesh{
+		Name: "bin",
+		Primitives: []gltfPrimitive{{
+			Attributes: map[string]int{"POSITION": posAcc},
+			Indices:    &idxAcc,
+			Mode:       gltfModeLines,
+		}},
+	})
+
+	nodeIdx := len(g.doc.Nodes)
+	g.doc.Nodes = append(g.doc.Nodes, gltfNode{Name: "bin", Mesh: &meshIdx})
+	g.doc.Scenes[0].Nodes = append(g.doc.Scenes[0].Nodes, nodeIdx)
+}
+
+// build finalizes the document, embedding the accumulated binary buffer as
+// a data URI, and marshals it to JSON.
+func (g *gltfBuilder) build() ([]byte, error) {
+	g.doc.Buffers = []gltfBuffer{{
+		URI:        "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(g.buf.Bytes()),
+		ByteLength: g.buf.Len(),
+	}}
+	return json.Marshal(g.doc)
+}
+
+// WriteGLTF renders bin b to a single-file glTF 2.0 JSON document (.gltf),
+// with each packed item exported as a colored box positioned at
+// item.Position and sized by item.GetDimension(), and the bin drawn as a
+// wireframe cage. The binary buffer is embedded as a data URI, so the
+// output is one self-contained file.
+func WriteGLTF(w io.Writer, b *bp3d.Bin) error {
+	g := newGLTFBuilder()
+	g.doc.Scenes = []gltfScene{{}}
+
+	g.addCageNode(bp3d.Dimension{b.GetWidth(), b.GetHeight(), b.GetDepth()})
+	for _, item := range b.Items {
+		r, gr, bl := itemColor(item.GetName())
+		g.addBoxNode(materialName(item), item.Position, item.GetDimension(), r, gr, bl)
+	}
+
+	out, err := g.build()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
@@ -0,0 +1,67 @@
+package viz
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tvanriper/bp3d"
+)
+
+func TestWriteGLTFBufferAndAccessorOffsets(t *testing.T) {
+	bin := bp3d.NewBin("bin", 10, 10, 10, 100)
+	item := bp3d.NewItem("item", 4, 4, 4, 1)
+	if fit, err := bin.PutItem(item, bp3d.Pivot{0, 0, 0}); !fit || err != nil {
+		t.Fatalf("PutItem() = %v, %v, want true, nil", fit, err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGLTF(&buf, bin); err != nil {
+		t.Fatalf("WriteGLTF() err = %v", err)
+	}
+
+	var doc gltfDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("json.Unmarshal() err = %v", err)
+	}
+
+	// One mesh/node for the bin cage, one for the packed item.
+	if len(doc.Meshes) != 2 {
+		t.Fatalf("len(doc.Meshes) = %d, want 2", len(doc.Meshes))
+	}
+	if len(doc.Nodes) != 2 {
+		t.Fatalf("len(doc.Nodes) = %d, want 2", len(doc.Nodes))
+	}
+	if len(doc.Materials) != 1 {
+		t.Fatalf("len(doc.Materials) = %d, want 1 (only the item box is materialized)", len(doc.Materials))
+	}
+
+	if len(doc.Buffers) != 1 {
+		t.Fatalf("len(doc.Buffers) = %d, want 1", len(doc.Buffers))
+	}
+	uri := doc.Buffers[0].URI
+	const prefix = "data:application/octet-stream;base64,"
+	if !strings.HasPrefix(uri, prefix) {
+		t.Fatalf("buffer URI = %q, want prefix %q", uri, prefix)
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(uri, prefix))
+	if err != nil {
+		t.Fatalf("base64 decode err = %v", err)
+	}
+	if len(raw) != doc.Buffers[0].ByteLength {
+		t.Errorf("decoded buffer length = %d, want %d (doc.Buffers[0].ByteLength)", len(raw), doc.Buffers[0].ByteLength)
+	}
+
+	for i, bv := range doc.BufferViews {
+		if bv.ByteOffset+bv.ByteLength > len(raw) {
+			t.Errorf("bufferView[%d] = {offset:%d, length:%d} exceeds buffer length %d", i, bv.ByteOffset, bv.ByteLength, len(raw))
+		}
+	}
+	for i, acc := range doc.Accessors {
+		if acc.BufferView < 0 || acc.BufferView >= len(doc.BufferViews) {
+			t.Errorf("accessor[%d].BufferView = %d out of range [0,%d)", i, acc.BufferView, len(doc.BufferViews))
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package viz
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+
+	"github.com/tvanriper/bp3d"
+)
+
+// htmlSceneItem is the JSON shape embedded in the HTML viewer for a single
+// packed item's box.
+type htmlSceneItem struct {
+	Name  string     `json:"name"`
+	Pos   [3]float64 `json:"pos"`
+	Dim   [3]float64 `json:"dim"`
+	Color [3]float64 `json:"color"`
+}
+
+type htmlScene struct {
+	Bin   [3]float64      `json:"bin"`
+	Items []htmlSceneItem `json:"items"`
+}
+
+// WriteHTML renders bin b as a single self-contained HTML page: the packed
+// items and bin dimensions are embedded as JSON, and a small hand-rolled
+// WebGL viewer (no external scripts or network access required) draws the
+// bin as a wireframe cage and each item as a colored box, orbitable with
+// the mouse. It's meant as a quick way to eyeball a PackResult without
+// leaving the browser.
+func WriteHTML(w io.Writer, b *bp3d.Bin) error {
+	scene := htmlScene{Bin: [3]float64{b.GetWidth(), b.GetHeight(), b.GetDepth()}, Items: []htmlSceneItem{}}
+	for _, item := range b.Items {
+		r, g, bl := itemColor(item.GetName())
+		d := item.GetDimension()
+		scene.Items = append(scene.Items, htmlSceneItem{
+			Name:  item.GetName(),
+			Pos:   [3]float64{item.Position[0], item.Position[1], item.Position[2]},
+			Dim:   [3]float64{d[0], d[1], d[2]},
+			Color: [3]float64{r, g, bl},
+		})
+	}
+
+	sceneJSON, err := json.Marshal(scene)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, htmlTemplate, html.EscapeString(b.GetName()), sceneJSON)
+	return err
+}
+
+// htmlTemplate is a minimal WebGL viewer: a vertex/fragment shader pair
+// that draws flat-colored triangles and lines, an orbit camera driven by
+// mouse drag, and a build step that turns the embedded scene JSON into
+// vertex buffers for the bin cage (lines) and each item (triangles).
+const htmlTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>bp3d: %s</title>
+<style>
+  html, body { margin: 0; height: 100%%; background: #111; overflow: hidden; }
+  canvas { display: block; width: 100%%; height: 100%%; }
+</style>
+</head>
+<body>
+<canvas id="c"></canvas>
+<script>
+const SCENE = %s;
+
+const canvas = document.getElementById('c');
+const gl = canvas.getContext('webgl');
+
+function resize() {
+  canvas.width = canvas.clientWidth * devicePixelRatio;
+  canvas.height = canvas.clientHeight * devicePixelRatio;
+  gl.viewport(0, 0, canvas.width, canvas.height);
+}
+window.addEventListener('resize', resize);
+resize();
+
+const vsSource = ` + "`" + `
+  attribute vec3 aPos;
+  attribute vec3 aColor;
+  uniform mat4 uMVP;
+  varying vec3 vColor;
+  void main() {
+    gl_Position = uMVP * vec4(aPos, 1.0);
+    vColor = aColor;
+  }
+` + "`" + `;
+const fsSource = ` + "`" + `
+  precision mediump float;
+  varying vec3 vColor;
+  void main() {
+    gl_FragColor = vec4(vColor, 1.0);
+  }
+` + "`" + `;
+
+function compile(type, src) {
+  const s = gl.createShader(type);
+  gl.shaderSource(s, src);
+  gl.compileShader(s);
+  if (!gl.getShaderParameter(s, gl.COMPILE_STATUS)) {
+    throw new Error(gl.getShaderInfoLog(s));
+  }
+  return s;
+}
+const prog = gl.createProgram();
+gl.attachShader(prog, compile(gl.VERTEX_SHADER, vsSource));
+gl.attachShader(prog, compile(gl.FRAGMENT_SHADER, fsSource));
+gl.linkProgram(prog);
+gl.useProgram(prog);
+
+const aPos = gl.getAttribLocation(prog, 'aPos');
+const aColor = gl.getAttribLocation(prog, 'aColor');
+const uMVP = gl.getUniformLocation(prog, 'uMVP');
+
+const BOX_CORNERS = [
+  [0,0,0],[1,0,0],[1,1,0],[0,1,0],
+  [0,0,1],[1,0,1],[1,1,1],[0,1,1],
+];
+const BOX_FACES = [
+  [0,1,2,3],[4,7,6,5],[0,4,5,1],[1,5,6,2],[2,6,7,3],[3,7,4,0],
+];
+const BOX_EDGES = [
+  [0,1],[1,2],[2,3],[3,0],[4,5],[5,6],[6,7],[7,4],[0,4],[1,5],[2,6],[3,7],
+];
+
+function pushBox(verts, pos, dim, color) {
+  const corners = BOX_CORNERS.map(o => [pos[0]+o[0]*dim[0], pos[1]+o[1]*dim[1], pos[2]+o[2]*dim[2]]);
+  for (const f of BOX_FACES) {
+    const tris = [[f[0],f[1],f[2]], [f[0],f[2],f[3]]];
+    for (const t of tris) {
+      for (const idx of t) {
+        verts.push(...corners[idx], ...color);
+      }
+    }
+  }
+}
+
+function pushWireBox(verts, pos, dim, color) {
+  const corners = BOX_CORNERS.map(o => [pos[0]+o[0]*dim[0], pos[1]+o[1]*dim[1], pos[2]+o[2]*dim[2]]);
+  for (const e of BOX_EDGES) {
+    verts.push(...corners[e[0]], ...color);
+    verts.push(...corners[e[1]], ...color);
+  }
+}
+
+const triVerts = [];
+for (const item of SCENE.items) {
+  pushBox(triVerts, item.pos, item.dim, item.color);
+}
+const lineVerts = [];
+pushWireBox(lineVerts, [0,0,0], SCENE.bin, [0.7, 0.7, 0.7]);
+
+function makeBuffer(data) {
+  const buf = gl.createBuffer();
+  gl.bindBuffer(gl.ARRAY_BUFFER, buf);
+  gl.bufferData(gl.ARRAY_BUFFER, new Float32Array(data), gl.STATIC_DRAW);
+  return buf;
+}
+const triBuf = makeBuffer(triVerts);
+const lineBuf = makeBuffer(lineVerts);
+
+function bindVertexLayout(buf) {
+  gl.bindBuffer(gl.ARRAY_BUFFER, buf);
+  gl.enableVertexAttribArray(aPos);
+  gl.vertexAttribPointer(aPos, 3, gl.FLOAT, false, 24, 0);
+  gl.enableVertexAttribArray(aColor);
+  gl.vertexAttribPointer(aColor, 3, gl.FLOAT, false, 24, 12);
+}
+
+// Minimal mat4 helpers (column-major, like WebGL expects).
+function perspective(fovy, aspect, near, far) {
+  const f = 1 / Math.tan(fovy / 2);
+  const nf = 1 / (near - far);
+  return [
+    f/aspect,0,0,0,
+    0,f,0,0,
+    0,0,(far+near)*nf,-1,
+    0,0,2*far*near*nf,0,
+  ];
+}
+function multiply(a, b) {
+  const out = new Array(16).fill(0);
+  for (let c = 0; c < 4; c++) {
+    for (let r = 0; r < 4; r++) {
+      let sum = 0;
+      for (let k = 0; k < 4; k++) sum += a[k*4+r] * b[c*4+k];
+      out[c*4+r] = sum;
+    }
+  }
+  return out;
+}
+function lookAt(eye, center, up) {
+  function sub(a,b){return [a[0]-b[0],a[1]-b[1],a[2]-b[2]];}
+  function norm(a){const l=Math.hypot(...a);return [a[0]/l,a[1]/l,a[2]/l];}
+  function cross(a,b){return [a[1]*b[2]-a[2]*b[1],a[2]*b[0]-a[0]*b[2],a[0]*b[1]-a[1]*b[0]];}
+  function dot(a,b){return a[0]*b[0]+a[1]*b[1]+a[2]*b[2];}
+  const zAxis = norm(sub(eye, center));
+  const xAxis = norm(cross(up, zAxis));
+  const yAxis = cross(zAxis, xAxis);
+  return [
+    xAxis[0],yAxis[0],zAxis[0],0,
+    xAxis[1],yAxis[1],zAxis[1],0,
+    xAxis[2],yAxis[2],zAxis[2],0,
+    -dot(xAxis,eye),-dot(yAxis,eye),-dot(zAxis,eye),1,
+  ];
+}
+
+let yaw = 0.6, pitch = 0.5;
+let dragging = false, lastX = 0, lastY = 0;
+canvas.addEventListener('mousedown', e => { dragging = true; lastX = e.clientX; lastY = e.clientY; });
+window.addEventListener('mouseup', () => dragging = false);
+window.addEventListener('mousemove', e => {
+  if (!dragging) return;
+  yaw += (e.clientX - lastX) * 0.01;
+  pitch += (e.clientY - lastY) * 0.01;
+  pitch = Math.max(-1.5, Math.min(1.5, pitch));
+  lastX = e.clientX; lastY = e.clientY;
+});
+
+const center = [SCENE.bin[0]/2, SCENE.bin[1]/2, SCENE.bin[2]/2];
+const radius = Math.hypot(SCENE.bin[0], SCENE.bin[1], SCENE.bin[2]) * 1.5 + 1;
+
+function draw() {
+  gl.enable(gl.DEPTH_TEST);
+  gl.clearColor(0.07, 0.07, 0.09, 1);
+  gl.clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT);
+
+  const eye = [
+    center[0] + radius * Math.cos(pitch) * Math.sin(yaw),
+    center[1] + radius * Math.sin(pitch),
+    center[2] + radius * Math.cos(pitch) * Math.cos(yaw),
+  ];
+  const view = lookAt(eye, center, [0, 1, 0]);
+  const proj = perspective(Math.PI/4, canvas.width/canvas.height, 0.1, radius*4);
+  const mvp = multiply(proj, view);
+  gl.uniformMatrix4fv(uMVP, false, mvp);
+
+  bindVertexLayout(triBuf);
+  gl.drawArrays(gl.TRIANGLES, 0, triVerts.length / 6);
+
+  bindVertexLayout(lineBuf);
+  gl.drawArrays(gl.LINES, 0, lineVerts.length / 6);
+
+  requestAnimationFrame(draw);
+}
+draw();
+</script>
+</body>
+</html>
+`
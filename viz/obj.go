@@ -0,0 +1,62 @@
+package viz
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/tvanriper/bp3d"
+)
+
+// WriteOBJ renders bin b to Wavefront OBJ, writing a colored box per packed
+// item (positioned at item.Position and sized by item.GetDimension(), so
+// rotations are respected) plus a wireframe cage for the bin itself. Items
+// are labeled via usemtl so they're identifiable when opened in Blender or
+// any other OBJ viewer. Vertex colors are written using the common
+// "v x y z r g b" extension; viewers that don't support it simply ignore
+// the trailing three fields.
+func WriteOBJ(w io.Writer, b *bp3d.Bin) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# bp3d OBJ export of bin %q (%gx%gx%g)\n", b.GetName(), b.GetWidth(), b.GetHeight(), b.GetDepth())
+
+	offset := 0
+	writeCage(bw, b, &offset)
+	for _, item := range b.Items {
+		writeItemBox(bw, item, &offset)
+	}
+
+	return bw.Flush()
+}
+
+// writeCage writes the bin's bounding box as a wireframe "g bin" group of
+// line segments, and advances *offset past the vertices it wrote.
+func writeCage(bw *bufio.Writer, b *bp3d.Bin, offset *int) {
+	corners := boxCorners(bp3d.Pivot{0, 0, 0}, bp3d.Dimension{b.GetWidth(), b.GetHeight(), b.GetDepth()})
+
+	fmt.Fprintf(bw, "g bin\n")
+	for _, c := range corners {
+		fmt.Fprintf(bw, "v %g %g %g\n", c[0], c[1], c[2])
+	}
+	for _, e := range boxEdges {
+		fmt.Fprintf(bw, "l %d %d\n", *offset+e[0]+1, *offset+e[1]+1)
+	}
+	*offset += len(corners)
+}
+
+// writeItemBox writes item as a named, colored, solid box group, and
+// advances *offset past the vertices it wrote.
+func writeItemBox(bw *bufio.Writer, item *bp3d.Item, offset *int) {
+	corners := boxCorners(item.Position, item.GetDimension())
+	r, g, bl := itemColor(item.GetName())
+
+	fmt.Fprintf(bw, "g %s\n", sanitizeName(item.GetName()))
+	fmt.Fprintf(bw, "usemtl %s\n", materialName(item))
+	for _, c := range corners {
+		fmt.Fprintf(bw, "v %g %g %g %g %g %g\n", c[0], c[1], c[2], r, g, bl)
+	}
+	for _, f := range boxQuadFaces {
+		fmt.Fprintf(bw, "f %d %d %d %d\n", *offset+f[0]+1, *offset+f[1]+1, *offset+f[2]+1, *offset+f[3]+1)
+	}
+	*offset += len(corners)
+}
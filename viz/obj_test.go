@@ -0,0 +1,61 @@
+package viz
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tvanriper/bp3d"
+)
+
+func TestWriteOBJVertexAndFaceCounts(t *testing.T) {
+	bin := bp3d.NewBin("bin", 10, 10, 10, 100)
+	item := bp3d.NewItem("item", 4, 4, 4, 1)
+	if fit, err := bin.PutItem(item, bp3d.Pivot{0, 0, 0}); !fit || err != nil {
+		t.Fatalf("PutItem() = %v, %v, want true, nil", fit, err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteOBJ(&buf, bin); err != nil {
+		t.Fatalf("WriteOBJ() err = %v", err)
+	}
+
+	var vCount, fCount, lCount int
+	var maxVertRef int
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "v":
+			vCount++
+		case "f":
+			fCount++
+			for _, f := range fields[1:] {
+				if n, err := strconv.Atoi(f); err == nil && n > maxVertRef {
+					maxVertRef = n
+				}
+			}
+		case "l":
+			lCount++
+		}
+	}
+
+	// 8 cage vertices + 8 item vertices, 6 item faces, 12 cage edges.
+	if vCount != 16 {
+		t.Errorf("vertex count = %d, want 16", vCount)
+	}
+	if fCount != 6 {
+		t.Errorf("face count = %d, want 6", fCount)
+	}
+	if lCount != 12 {
+		t.Errorf("edge count = %d, want 12", lCount)
+	}
+	if maxVertRef > vCount {
+		t.Errorf("face references vertex %d, but only %d vertices were written (1-indexed)", maxVertRef, vCount)
+	}
+}